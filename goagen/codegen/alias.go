@@ -0,0 +1,68 @@
+package codegen
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/goadesign/goa/design"
+)
+
+// EmitTypeAliases controls whether GoTypeDef emits Go 1.9 type aliases ("type X = Y") rather
+// than named types ("type X Y") for user types and media types that simply rename another user
+// type or a type bound via Binder. It has no effect on a toolchain that predates Go 1.9 (see
+// goSupportsTypeAliases): such targets always get the named-type form. A given type can also be
+// forced into alias form regardless of this flag with the "struct:type:alias" metadata key, set
+// in the design with Metadata("struct:type:alias"); apidsl has no dedicated DSL function for it.
+var EmitTypeAliases bool
+
+// aliasMetadataKey is the Metadata key that forces alias emission for a single type regardless
+// of EmitTypeAliases.
+const aliasMetadataKey = "struct:type:alias"
+
+// shouldEmitAlias returns true if def, the definition being emitted (not the type it points to),
+// should be rendered as a Go 1.9 type alias rather than a named type. The "struct:type:alias"
+// Metadata override is read from def itself, so that setting that key on a given type only
+// forces alias emission for that type's own definition, not for every other
+// definition that happens to point to it.
+func shouldEmitAlias(def *design.AttributeDefinition) bool {
+	if !goSupportsTypeAliases() {
+		return false
+	}
+	if _, ok := def.Metadata[aliasMetadataKey]; ok {
+		return true
+	}
+	return EmitTypeAliases
+}
+
+// goSupportsTypeAliases returns true if the Go toolchain running the generator is 1.9 or later,
+// i.e. supports the "type X = Y" alias declaration.
+func goSupportsTypeAliases() bool {
+	major, minor, ok := parseGoVersion(runtime.Version())
+	if !ok {
+		// Can't tell, e.g. a non-standard toolchain version string: assume modern enough.
+		return true
+	}
+	return major > 1 || (major == 1 && minor >= 9)
+}
+
+// parseGoVersion extracts the major and minor version numbers from a runtime.Version() string
+// such as "go1.9.2" or "go1.21".
+func parseGoVersion(v string) (major, minor int, ok bool) {
+	v = strings.TrimPrefix(v, "go")
+	parts := strings.SplitN(v, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err = strconv.Atoi(strings.TrimRightFunc(parts[1], func(r rune) bool {
+		return r < '0' || r > '9'
+	}))
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
@@ -0,0 +1,122 @@
+package codegen
+
+import (
+	"sort"
+
+	"github.com/goadesign/goa/design"
+)
+
+// TypeBinding describes the Go type that a design type should be rendered as in generated code.
+// Name is the Go type name (including any package qualifier the caller wants rendered verbatim,
+// e.g. "decimal.Decimal"). ImportPath is the import path that must be added to the generated file
+// for Name to resolve, it is left empty for types that require no import (builtins or types
+// already declared in the generated package).
+type TypeBinding struct {
+	// Name is the Go type name used to reference the bound type.
+	Name string
+	// ImportPath is the import path to add to the generated file, empty if none is needed.
+	ImportPath string
+	// GraphQLScalar is the name of the GraphQL scalar that represents the bound type, e.g.
+	// "Time" for a DateTime bound to civil.DateTime. Consumed by codegen/graphql, it is left
+	// empty for bindings that have no corresponding custom scalar.
+	GraphQLScalar string
+}
+
+// Binder maps design DSL primitives and user types to the Go types used to represent them in
+// generated code. A zero value Binder has no bindings and causes GoNativeType, GoTypeName,
+// GoTypeRef and GoPackageTypeName to fall back to their built-in behavior.
+type Binder struct {
+	kinds map[design.Kind]TypeBinding
+	users map[string]TypeBinding
+}
+
+// NewBinder returns an empty Binder ready for use with BindKind and BindUserType.
+func NewBinder() *Binder {
+	return &Binder{
+		kinds: make(map[design.Kind]TypeBinding),
+		users: make(map[string]TypeBinding),
+	}
+}
+
+// DefaultBinder is the Binder consulted by GoNativeType, GoTypeName, GoTypeRef and
+// GoPackageTypeName. Generators that want to bind DSL primitives or user types to custom Go
+// types should call BindKind/BindUserType on it (or replace it outright) before running codegen.
+var DefaultBinder = NewBinder()
+
+// BindKind binds every occurrence of the given design.Kind (e.g. design.IntegerKind) to t. It
+// overrides the default native Go type (int, float64, time.Time, etc.) used by GoNativeType and
+// friends.
+func (b *Binder) BindKind(k design.Kind, t TypeBinding) {
+	b.kinds[k] = t
+}
+
+// BindUserType binds the user type with the given name (UserTypeDefinition.TypeName) to t, so that
+// GoNativeType, GoTypeName, GoTypeRef and GoPackageTypeName render t.Name wherever the user type
+// is referenced. GoTypeDef does not consult bindings and still renders the type's own definition.
+func (b *Binder) BindUserType(name string, t TypeBinding) {
+	b.users[name] = t
+}
+
+// KindBinding returns the TypeBinding registered for k and true, or the zero value and false if
+// none was registered.
+func (b *Binder) KindBinding(k design.Kind) (TypeBinding, bool) {
+	if b == nil {
+		return TypeBinding{}, false
+	}
+	t, ok := b.kinds[k]
+	return t, ok
+}
+
+// UserTypeBinding returns the TypeBinding registered for the user type named name and true, or
+// the zero value and false if none was registered.
+func (b *Binder) UserTypeBinding(name string) (TypeBinding, bool) {
+	if b == nil {
+		return TypeBinding{}, false
+	}
+	t, ok := b.users[name]
+	return t, ok
+}
+
+// Imports returns the sorted, de-duplicated list of import paths required by the bindings
+// registered on b. Generators should add the result to the generated file's import block.
+func (b *Binder) Imports() []string {
+	if b == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var imports []string
+	for _, t := range b.kinds {
+		if t.ImportPath != "" && !seen[t.ImportPath] {
+			seen[t.ImportPath] = true
+			imports = append(imports, t.ImportPath)
+		}
+	}
+	for _, t := range b.users {
+		if t.ImportPath != "" && !seen[t.ImportPath] {
+			seen[t.ImportPath] = true
+			imports = append(imports, t.ImportPath)
+		}
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// boundUserTypeName returns the Go type name bound to the user type definition ut, if any, and
+// whether a binding was found.
+func boundUserTypeName(ut *design.UserTypeDefinition) (string, bool) {
+	t, ok := DefaultBinder.UserTypeBinding(ut.TypeName)
+	if !ok {
+		return "", false
+	}
+	return t.Name, true
+}
+
+// boundKindName returns the Go type name bound to the primitive kind k, if any, and whether a
+// binding was found.
+func boundKindName(k design.Kind) (string, bool) {
+	t, ok := DefaultBinder.KindBinding(k)
+	if !ok {
+		return "", false
+	}
+	return t.Name, true
+}
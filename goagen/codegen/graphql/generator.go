@@ -0,0 +1,81 @@
+package graphql
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goadesign/goa/design"
+	"github.com/goadesign/goa/goagen/codegen"
+)
+
+// handlerSource is the static Go source of the generated http.Handler stub. The handler holds the
+// application-supplied Resolver but does not execute GraphQL queries or mutations: it answers
+// every request, on any path, with 501 Not Implemented.
+const handlerSource = `// NewHandler returns an http.Handler stub holding r. It does not execute GraphQL queries or
+// mutations: every request, on any path, gets a 501 Not Implemented response.
+func NewHandler(r Resolver) http.Handler {
+	return &handler{resolver: r}
+}
+
+type handler struct {
+	resolver Resolver
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// Applications should replace this with a real GraphQL execution engine (e.g.
+	// graphql-go/graphql or graph-gophers/graphql-go) configured with the Schema and h.resolver.
+	http.Error(w, "graphql execution not implemented", http.StatusNotImplemented)
+}
+`
+
+// Generate writes the GraphQL SDL schema, Go resolver interface and http.Handler stub for api
+// into outDir, creating it if necessary, and returns the paths of the generated files.
+func Generate(api *design.APIDefinition, outDir string) ([]string, error) {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return nil, fmt.Errorf("graphql: failed to create output directory: %s", err)
+	}
+
+	schemaPath := filepath.Join(outDir, "schema.graphql")
+	if err := ioutil.WriteFile(schemaPath, []byte(BuildSchema(api)), 0644); err != nil {
+		return nil, fmt.Errorf("graphql: failed to write schema: %s", err)
+	}
+
+	resolverPath := filepath.Join(outDir, "resolver.go")
+	// Resolver method signatures are built with codegen.GoPackageTypeRef (see
+	// BuildResolverInterface), which renders the Go type names that codegen.DefaultBinder has
+	// bound design types to (e.g. "decimal.Decimal" for a DateTime bound to
+	// github.com/shopspring/decimal) verbatim; resolver.go needs the corresponding imports
+	// alongside "context" for those names to resolve.
+	resolverSrc := "package graphql\n\n" + importBlock("context") + "\n" + BuildResolverInterface(api)
+	if err := ioutil.WriteFile(resolverPath, []byte(resolverSrc), 0644); err != nil {
+		return nil, fmt.Errorf("graphql: failed to write resolver: %s", err)
+	}
+
+	handlerPath := filepath.Join(outDir, "handler.go")
+	handlerSrc := "package graphql\n\nimport \"net/http\"\n\n" + handlerSource
+	if err := ioutil.WriteFile(handlerPath, []byte(handlerSrc), 0644); err != nil {
+		return nil, fmt.Errorf("graphql: failed to write handler: %s", err)
+	}
+
+	return []string{schemaPath, resolverPath, handlerPath}, nil
+}
+
+// importBlock renders a Go import declaration for std, the standard library import every
+// generated file in this package needs (e.g. "context" or "net/http"), plus any import path
+// required by a binding registered on codegen.DefaultBinder (see Binder.Imports).
+func importBlock(std string) string {
+	paths := append([]string{std}, codegen.DefaultBinder.Imports()...)
+	if len(paths) == 1 {
+		return fmt.Sprintf("import %q\n", paths[0])
+	}
+	var b strings.Builder
+	b.WriteString("import (\n")
+	for _, p := range paths {
+		fmt.Fprintf(&b, "\t%q\n", p)
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
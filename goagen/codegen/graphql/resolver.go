@@ -0,0 +1,114 @@
+package graphql
+
+import (
+	"text/template"
+
+	"github.com/goadesign/goa/design"
+	"github.com/goadesign/goa/goagen/codegen"
+)
+
+// resolverParam describes one parameter of a generated Resolver method, taken from the
+// corresponding action's path/query params or request payload.
+type resolverParam struct {
+	// Name is the Go parameter name, e.g. "id".
+	Name string
+	// Type is the Go type reference of the parameter.
+	Type string
+}
+
+// resolverMethod describes one method of the generated Resolver interface.
+type resolverMethod struct {
+	// Method is the Go method name, e.g. "BottleShow".
+	Method string
+	// Params are the method's parameters following ctx context.Context, taken from the action's
+	// params and payload so e.g. a "show" action keyed by id produces BottleShow(ctx
+	// context.Context, id int) rather than leaving no way to say which resource to resolve.
+	Params []resolverParam
+	// Return is the Go type reference of the method's first return value.
+	Return string
+}
+
+var resolverInterfaceTmpl = template.Must(template.New("resolver").Parse(`// Resolver is implemented by the application to answer the GraphQL queries and mutations
+// generated from the goa design. Each method corresponds to one action exposed as a Query or
+// Mutation field.
+type Resolver interface {
+{{range .}}	{{.Method}}(ctx context.Context{{range .Params}}, {{.Name}} {{.Type}}{{end}}) ({{.Return}}, error)
+{{end}}}
+`))
+
+// BuildResolverInterface renders the Go source of the Resolver interface that applications must
+// implement to serve the schema returned by BuildSchema, one method per Query/Mutation field.
+// Method signatures reuse codegen.GoPackageTypeRef so they match the struct types generated by
+// goagen for the same design.
+func BuildResolverInterface(api *design.APIDefinition) string {
+	var methods []resolverMethod
+	if api != nil {
+		for _, resName := range sortedResourceNames(api) {
+			res := api.Resources[resName]
+			for _, actName := range sortedActionNames(res) {
+				act := res.Actions[actName]
+				methods = append(methods, resolverMethod{
+					Method: codegen.Goify(actionFieldName(res.Name, act.Name), true),
+					Params: resolverParams(act),
+					Return: resolverReturnType(api, act),
+				})
+			}
+		}
+	}
+	return codegen.RunTemplate(resolverInterfaceTmpl, methods)
+}
+
+// resolverParams returns the Go parameters for act's resolver method: one per act.Params
+// attribute followed by one per act.Payload attribute, in the same order BuildSchema lists them as
+// GraphQL field arguments (see schemaBuilder.actionArgs), so the two stay in lockstep.
+func resolverParams(act *design.ActionDefinition) []resolverParam {
+	var params []resolverParam
+	if act.Params != nil {
+		params = append(params, objectParams(act.Params.Type, act.Params)...)
+	}
+	if act.Payload != nil {
+		params = append(params, objectParams(act.Payload.Type, act.Payload.AttributeDefinition)...)
+	}
+	return params
+}
+
+// objectParams returns one resolverParam per attribute of t (expected to be a design.Object, the
+// shape both act.Params and act.Payload take).
+func objectParams(t design.DataType, parent *design.AttributeDefinition) []resolverParam {
+	obj, ok := t.(design.Object)
+	if !ok {
+		return nil
+	}
+	params := make([]resolverParam, 0, len(obj))
+	for _, name := range sortedObjectAttributeNames(obj) {
+		params = append(params, resolverParam{
+			Name: codegen.Goify(name, false),
+			Type: goTypeRefFor(obj[name]),
+		})
+	}
+	return params
+}
+
+// goTypeRefFor returns the Go type reference for att, passing along the nested-required list
+// GoPackageTypeRef/GoPackageTypeName need to render an inline object's fields for user and media
+// types, and nil (no inline object fields of its own to worry about) for everything else.
+func goTypeRefFor(att *design.AttributeDefinition) string {
+	switch actual := att.Type.(type) {
+	case *design.UserTypeDefinition:
+		return codegen.GoTypeRef(actual, actual.AllRequired(), 0)
+	case *design.MediaTypeDefinition:
+		return codegen.GoTypeRef(actual, actual.AllRequired(), 0)
+	default:
+		return codegen.GoTypeRef(att.Type, nil, 0)
+	}
+}
+
+// resolverReturnType returns the Go type reference of act's successful response, "bool" for
+// actions with no response media type.
+func resolverReturnType(api *design.APIDefinition, act *design.ActionDefinition) string {
+	mt := successMediaType(api, act)
+	if mt == nil {
+		return "bool"
+	}
+	return codegen.GoPackageTypeRef(mt, mt.AllRequired(), false, "", 0)
+}
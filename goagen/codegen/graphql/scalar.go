@@ -0,0 +1,41 @@
+package graphql
+
+import (
+	"github.com/goadesign/goa/design"
+	"github.com/goadesign/goa/goagen/codegen"
+)
+
+// builtinScalars maps design.Kind to the default GraphQL scalar used to represent it, absent a
+// more specific binding registered on codegen.DefaultBinder (see scalarName).
+var builtinScalars = map[design.Kind]string{
+	design.BooleanKind:  "Boolean",
+	design.IntegerKind:  "Int",
+	design.NumberKind:   "Float",
+	design.StringKind:   "String",
+	design.DateTimeKind: "Time",
+	design.AnyKind:      "String",
+}
+
+// specScalars lists the scalar names defined by the GraphQL specification itself (Int, Float,
+// String, Boolean, ID); unlike every other scalar name that can come out of scalarName (e.g. the
+// default "Time"), these never need a "scalar" declaration in the generated SDL.
+var specScalars = map[string]bool{
+	"Int":     true,
+	"Float":   true,
+	"String":  true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// scalarName returns the GraphQL scalar name to use for the primitive kind k. A GraphQLScalar
+// registered on codegen.DefaultBinder for k takes precedence over builtinScalars, so e.g. binding
+// DateTime to civil.DateTime can also rename its scalar away from the default "Time".
+func scalarName(k design.Kind) string {
+	if t, ok := codegen.DefaultBinder.KindBinding(k); ok && t.GraphQLScalar != "" {
+		return t.GraphQLScalar
+	}
+	if s, ok := builtinScalars[k]; ok {
+		return s
+	}
+	return "String"
+}
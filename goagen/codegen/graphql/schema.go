@@ -0,0 +1,348 @@
+// Package graphql generates a GraphQL SDL schema, Go resolver stubs and an http.Handler from a
+// goa design.APIDefinition. It walks the same design graph as goagen/codegen: resources with GET
+// actions become Query fields, resources with mutating actions (POST, PUT, PATCH, DELETE) become
+// Mutation fields, media types become GraphQL object types (selectable fields taken from the
+// "default" view), arrays map to GraphQL list types and required attributes drive non-null ("!").
+package graphql
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/goadesign/goa/design"
+	"github.com/goadesign/goa/goagen/codegen"
+)
+
+// mutatingVerbs lists the HTTP verbs whose actions are exposed as GraphQL Mutation fields rather
+// than Query fields.
+var mutatingVerbs = map[string]bool{
+	"POST":   true,
+	"PUT":    true,
+	"PATCH":  true,
+	"DELETE": true,
+}
+
+// schemaBuilder accumulates the GraphQL object type declarations and custom scalar names
+// referenced while walking api, so BuildSchema can emit a "scalar X" declaration for every custom
+// scalar actually used (see scalarName/specScalars) in addition to the type declarations.
+type schemaBuilder struct {
+	objects map[string]string
+	scalars map[string]bool
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		objects: make(map[string]string),
+		scalars: make(map[string]bool),
+	}
+}
+
+// BuildSchema renders the GraphQL SDL for api: a "scalar" declaration for every custom scalar
+// referenced, a "type Query", optionally a "type Mutation", and one "type" declaration per media
+// type and user type reachable from an action.
+func BuildSchema(api *design.APIDefinition) string {
+	var buf bytes.Buffer
+	b := newSchemaBuilder()
+
+	b.collectObjectTypes(api)
+	queries, mutations := b.collectFields(api)
+
+	for _, name := range sortedScalarNames(b.scalars) {
+		buf.WriteString(fmt.Sprintf("scalar %s\n", name))
+	}
+	if len(b.scalars) > 0 {
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("type Query {\n")
+	writeFields(&buf, queries)
+	buf.WriteString("}\n")
+
+	if len(mutations) > 0 {
+		buf.WriteString("\ntype Mutation {\n")
+		writeFields(&buf, mutations)
+		buf.WriteString("}\n")
+	}
+
+	for _, name := range sortedKeys(b.objects) {
+		buf.WriteString("\n")
+		buf.WriteString(b.objects[name])
+	}
+
+	return buf.String()
+}
+
+// schemaField is a single Query or Mutation field: "name(args): Type".
+type schemaField struct {
+	name string
+	args string
+	typ  string
+}
+
+// schemaArg is a single GraphQL field argument: "name: Type".
+type schemaArg struct {
+	name string
+	typ  string
+}
+
+// collectFields walks api's resources and actions and splits their routes into Query and
+// Mutation fields based on the action's HTTP verb.
+func (b *schemaBuilder) collectFields(api *design.APIDefinition) (queries, mutations []schemaField) {
+	if api == nil {
+		return nil, nil
+	}
+	for _, resName := range sortedResourceNames(api) {
+		res := api.Resources[resName]
+		for _, actName := range sortedActionNames(res) {
+			act := res.Actions[actName]
+			field := schemaField{
+				name: actionFieldName(res.Name, act.Name),
+				args: argsString(b.actionArgs(act)),
+				typ:  b.responseType(api, act),
+			}
+			if isMutation(act) {
+				mutations = append(mutations, field)
+			} else {
+				queries = append(queries, field)
+			}
+		}
+	}
+	return queries, mutations
+}
+
+// actionArgs returns the GraphQL field arguments for act: one per act.Params attribute (path and
+// query parameters) followed by one per act.Payload attribute (request body), in that order, each
+// sorted alphabetically by name for reproducible output. This is how a "show"/"update"/"delete"
+// action keyed by id (or carrying an update body) gets a usable "(id: Int!)"-style argument list
+// instead of none at all.
+func (b *schemaBuilder) actionArgs(act *design.ActionDefinition) []schemaArg {
+	var args []schemaArg
+	if act.Params != nil {
+		args = append(args, b.objectArgs(act.Params.Type, act.Params)...)
+	}
+	if act.Payload != nil {
+		args = append(args, b.objectArgs(act.Payload.Type, act.Payload.AttributeDefinition)...)
+	}
+	return args
+}
+
+// objectArgs returns one schemaArg per attribute of t (expected to be a design.Object, the shape
+// both act.Params and act.Payload take), reading required-ness off of parent.
+func (b *schemaBuilder) objectArgs(t design.DataType, parent *design.AttributeDefinition) []schemaArg {
+	obj, ok := t.(design.Object)
+	if !ok {
+		return nil
+	}
+	args := make([]schemaArg, 0, len(obj))
+	for _, name := range sortedObjectAttributeNames(obj) {
+		args = append(args, schemaArg{
+			name: codegen.Goify(name, false),
+			typ:  b.fieldType(obj[name], parent.IsRequired(name)),
+		})
+	}
+	return args
+}
+
+// argsString renders args as the parenthesized GraphQL argument list for a field declaration, or
+// "" if there are none.
+func argsString(args []schemaArg) string {
+	if len(args) == 0 {
+		return ""
+	}
+	parts := make([]string, len(args))
+	for i, a := range args {
+		parts[i] = fmt.Sprintf("%s: %s", a.name, a.typ)
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// isMutation returns true if act should be exposed as a GraphQL Mutation field rather than a
+// Query field, based on the HTTP verbs of its routes.
+func isMutation(act *design.ActionDefinition) bool {
+	for _, r := range act.Routes {
+		if mutatingVerbs[r.Verb] {
+			return true
+		}
+	}
+	return false
+}
+
+// actionFieldName computes the GraphQL field name for an action, e.g. resource "bottle" and
+// action "show" becomes "bottleShow".
+func actionFieldName(resource, action string) string {
+	return codegen.Goify(resource, false) + codegen.Goify(action, true)
+}
+
+// responseType returns the GraphQL type reference of act's successful response media type,
+// falling back to "Boolean" for actions that return no payload.
+func (b *schemaBuilder) responseType(api *design.APIDefinition, act *design.ActionDefinition) string {
+	mt := successMediaType(api, act)
+	if mt == nil {
+		return "Boolean"
+	}
+	att := &design.AttributeDefinition{Type: mt}
+	return b.fieldType(att, false)
+}
+
+// successMediaType returns the media type of the first 2xx response defined on act, resolved
+// against api.MediaTypes, or nil if act has no such response or the response names a media type
+// that is not defined in the design (the DSL validates against this, so that should not happen in
+// practice).
+func successMediaType(api *design.APIDefinition, act *design.ActionDefinition) *design.MediaTypeDefinition {
+	for _, name := range sortedResponseNames(act) {
+		resp := act.Responses[name]
+		if resp.Status < 200 || resp.Status >= 300 {
+			continue
+		}
+		if resp.MediaType == "" {
+			continue
+		}
+		if mt, ok := api.MediaTypes[resp.MediaType]; ok {
+			return mt
+		}
+	}
+	return nil
+}
+
+// writeFields writes one GraphQL field declaration per entry in fields, sorted by name for
+// reproducible output.
+func writeFields(buf *bytes.Buffer, fields []schemaField) {
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+	for _, f := range fields {
+		buf.WriteString(fmt.Sprintf("\t%s%s: %s\n", f.name, f.args, f.typ))
+	}
+}
+
+// collectObjectTypes walks every media type reachable from api's actions and renders a GraphQL
+// "type" declaration for each, keyed by GraphQL type name.
+func (b *schemaBuilder) collectObjectTypes(api *design.APIDefinition) {
+	if api == nil {
+		return
+	}
+	for _, resName := range sortedResourceNames(api) {
+		res := api.Resources[resName]
+		for _, actName := range sortedActionNames(res) {
+			act := res.Actions[actName]
+			if mt := successMediaType(api, act); mt != nil {
+				b.renderObjectType(mt)
+			}
+		}
+	}
+}
+
+// renderObjectType renders the GraphQL object type for mt's default view and stores it in
+// b.objects, recursing into any media or user types it references.
+//
+// A collection media type (mt.Type is a *design.Array, e.g. the media type produced by
+// CollectionOf(...)) has no fields of its own -- its GraphQL representation is a list of the
+// element type (see fieldType) rather than an object type, so this only recurses into the element
+// and never adds an entry for the collection's own name.
+func (b *schemaBuilder) renderObjectType(mt *design.MediaTypeDefinition) {
+	if arr, ok := mt.Type.(*design.Array); ok {
+		if elem, ok := arr.ElemType.Type.(*design.MediaTypeDefinition); ok {
+			b.renderObjectType(elem)
+		}
+		return
+	}
+
+	name := graphqlTypeName(mt.TypeName)
+	if _, done := b.objects[name]; done {
+		return
+	}
+	b.objects[name] = "" // mark in-progress to break reference cycles
+
+	attNames := viewAttributeNames(mt)
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("type %s {\n", name))
+	for _, attName := range attNames {
+		att, ok := mt.Type.ToObject()[attName]
+		if !ok {
+			continue
+		}
+		required := mt.IsRequired(attName)
+		buf.WriteString(fmt.Sprintf("\t%s: %s\n", codegen.Goify(attName, false), b.fieldType(att, required)))
+	}
+	buf.WriteString("}\n")
+	b.objects[name] = buf.String()
+}
+
+// viewAttributeNames returns, in alphabetical order, the attribute names to render for mt: those
+// selected by its "default" view if it has one, those selected by its alphabetically first view if
+// it has views but none named "default" (picked deterministically rather than via Go's
+// unspecified map iteration order), or every attribute of mt's underlying object type if mt has no
+// views at all (e.g. a collection element's singular media type built without an explicit view,
+// which would otherwise leave nothing to index into and panic).
+func viewAttributeNames(mt *design.MediaTypeDefinition) []string {
+	if view, ok := mt.Views["default"]; ok {
+		return sortedAttributeNames(view)
+	}
+	if names := sortedViewNames(mt); len(names) > 0 {
+		return sortedAttributeNames(mt.Views[names[0]])
+	}
+	return sortedObjectAttributeNames(mt.Type.ToObject())
+}
+
+// renderUserType renders the GraphQL object type for the plain (non-media) user type ut and
+// stores it in b.objects, recursing into any media or user types it references. Unlike
+// renderObjectType, ut has no views: every attribute of its underlying object type is exposed.
+func (b *schemaBuilder) renderUserType(ut *design.UserTypeDefinition) {
+	name := graphqlTypeName(ut.TypeName)
+	if _, done := b.objects[name]; done {
+		return
+	}
+	b.objects[name] = "" // mark in-progress to break reference cycles
+
+	obj := ut.Type.ToObject()
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("type %s {\n", name))
+	for _, attName := range sortedObjectAttributeNames(obj) {
+		att := obj[attName]
+		required := ut.IsRequired(attName)
+		buf.WriteString(fmt.Sprintf("\t%s: %s\n", codegen.Goify(attName, false), b.fieldType(att, required)))
+	}
+	buf.WriteString("}\n")
+	b.objects[name] = buf.String()
+}
+
+// fieldType returns the GraphQL type reference for att, recursing into nested media and user
+// types (rendering their object type declarations as a side effect) and honoring array/
+// required-ness. Scalar types that are not part of the GraphQL specification (see specScalars)
+// are recorded in b.scalars so BuildSchema can declare them.
+func (b *schemaBuilder) fieldType(att *design.AttributeDefinition, required bool) string {
+	var typ string
+	switch actual := att.Type.(type) {
+	case *design.Array:
+		typ = "[" + b.fieldType(actual.ElemType, true) + "]"
+	case *design.MediaTypeDefinition:
+		b.renderObjectType(actual)
+		if arr, ok := actual.Type.(*design.Array); ok {
+			typ = "[" + b.fieldType(arr.ElemType, true) + "]"
+		} else {
+			typ = graphqlTypeName(actual.TypeName)
+		}
+	case *design.UserTypeDefinition:
+		b.renderUserType(actual)
+		typ = graphqlTypeName(actual.TypeName)
+	case design.Primitive:
+		name := scalarName(actual.Kind())
+		if !specScalars[name] {
+			b.scalars[name] = true
+		}
+		typ = name
+	default:
+		typ = "String"
+	}
+	if required {
+		typ += "!"
+	}
+	return typ
+}
+
+// graphqlTypeName derives the GraphQL object type name from a goa type name, e.g. "bottle" (the
+// raw DSL MediaType identifier) becomes "Bottle".
+func graphqlTypeName(name string) string {
+	return codegen.Goify(name, true)
+}
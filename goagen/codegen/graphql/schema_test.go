@@ -0,0 +1,165 @@
+package graphql
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goadesign/goa/design"
+	"github.com/goadesign/goa/dslengine"
+)
+
+// requiredAttribute returns an *AttributeDefinition wrapping obj with names marked required,
+// matching the pattern types.go uses to thread required-ness through an inline design.Object.
+func requiredAttribute(obj design.Object, names ...string) *design.AttributeDefinition {
+	att := &design.AttributeDefinition{Type: obj}
+	if len(names) > 0 {
+		att.Validations = append(att.Validations, &dslengine.RequiredValidationDefinition{Names: names})
+	}
+	return att
+}
+
+// TestBuildSchema_ActionArgs covers the gap reported in review: a "show" action keyed by id and
+// an "update" action carrying a payload must surface those as GraphQL field arguments and not
+// just an empty "()" .
+func TestBuildSchema_ActionArgs(t *testing.T) {
+	bottleMT := &design.MediaTypeDefinition{
+		UserTypeDefinition: &design.UserTypeDefinition{
+			AttributeDefinition: &design.AttributeDefinition{
+				Type: design.Object{
+					"id":   &design.AttributeDefinition{Type: design.Integer},
+					"name": &design.AttributeDefinition{Type: design.String},
+				},
+			},
+			TypeName: "bottle",
+		},
+		Views: map[string]*design.ViewDefinition{
+			"default": {
+				AttributeDefinition: &design.AttributeDefinition{
+					Type: design.Object{
+						"id":   &design.AttributeDefinition{},
+						"name": &design.AttributeDefinition{},
+					},
+				},
+			},
+		},
+	}
+
+	showAction := &design.ActionDefinition{
+		Name:   "show",
+		Routes: []*design.RouteDefinition{{Verb: "GET"}},
+		Responses: map[string]*design.ResponseDefinition{
+			"OK": {Status: 200, MediaType: "bottle"},
+		},
+		Params: requiredAttribute(design.Object{
+			"id": &design.AttributeDefinition{Type: design.Integer},
+		}, "id"),
+	}
+
+	updateAction := &design.ActionDefinition{
+		Name:   "update",
+		Routes: []*design.RouteDefinition{{Verb: "PUT"}},
+		Responses: map[string]*design.ResponseDefinition{
+			"OK": {Status: 200, MediaType: "bottle"},
+		},
+		Params: requiredAttribute(design.Object{
+			"id": &design.AttributeDefinition{Type: design.Integer},
+		}, "id"),
+		Payload: &design.UserTypeDefinition{
+			AttributeDefinition: requiredAttribute(design.Object{
+				"name": &design.AttributeDefinition{Type: design.String},
+			}, "name"),
+			TypeName: "bottlePayload",
+		},
+	}
+
+	api := &design.APIDefinition{
+		Resources: map[string]*design.ResourceDefinition{
+			"bottle": {
+				Name: "bottle",
+				Actions: map[string]*design.ActionDefinition{
+					"show":   showAction,
+					"update": updateAction,
+				},
+			},
+		},
+		MediaTypes: map[string]*design.MediaTypeDefinition{
+			"bottle": bottleMT,
+		},
+	}
+
+	schema := BuildSchema(api)
+
+	if !strings.Contains(schema, "bottleShow(id: Int!): Bottle") {
+		t.Errorf("expected bottleShow to take an id argument, got schema:\n%s", schema)
+	}
+	if !strings.Contains(schema, "bottleUpdate(id: Int!, name: String!): Bottle") {
+		t.Errorf("expected bottleUpdate to take id and payload arguments, got schema:\n%s", schema)
+	}
+
+	resolver := BuildResolverInterface(api)
+	if !strings.Contains(resolver, "BottleShow(ctx context.Context, id int) (*Bottle, error)") {
+		t.Errorf("expected BottleShow to take an id parameter, got resolver:\n%s", resolver)
+	}
+	if !strings.Contains(resolver, "BottleUpdate(ctx context.Context, id int, name string) (*Bottle, error)") {
+		t.Errorf("expected BottleUpdate to take id and name parameters, got resolver:\n%s", resolver)
+	}
+}
+
+// TestBuildSchema_ViewlessMediaType covers the nil pointer dereference reported in review:
+// BuildSchema must not panic when a media type reachable from an action (e.g. a collection
+// element's singular media type) has no Views at all, and must still render its attributes.
+func TestBuildSchema_ViewlessMediaType(t *testing.T) {
+	elemMT := &design.MediaTypeDefinition{
+		UserTypeDefinition: &design.UserTypeDefinition{
+			AttributeDefinition: &design.AttributeDefinition{
+				Type: design.Object{
+					"id": &design.AttributeDefinition{Type: design.Integer},
+				},
+			},
+			TypeName: "bottle",
+		},
+		// Views is deliberately left nil: no explicit view was defined for this media type.
+	}
+	collectionMT := &design.MediaTypeDefinition{
+		UserTypeDefinition: &design.UserTypeDefinition{
+			AttributeDefinition: &design.AttributeDefinition{
+				Type: &design.Array{
+					ElemType: &design.AttributeDefinition{Type: elemMT},
+				},
+			},
+			TypeName: "bottleCollection",
+		},
+	}
+
+	api := &design.APIDefinition{
+		Resources: map[string]*design.ResourceDefinition{
+			"bottle": {
+				Name: "bottle",
+				Actions: map[string]*design.ActionDefinition{
+					"list": {
+						Name:   "list",
+						Routes: []*design.RouteDefinition{{Verb: "GET"}},
+						Responses: map[string]*design.ResponseDefinition{
+							"OK": {Status: 200, MediaType: "bottleCollection"},
+						},
+					},
+				},
+			},
+		},
+		MediaTypes: map[string]*design.MediaTypeDefinition{
+			"bottleCollection": collectionMT,
+		},
+	}
+
+	schema := BuildSchema(api) // must not panic
+
+	if !strings.Contains(schema, "type Bottle {") {
+		t.Errorf("expected a Bottle type declaration for the view-less media type, got schema:\n%s", schema)
+	}
+	if !strings.Contains(schema, "\tid: Int\n") {
+		t.Errorf("expected the view-less media type's attribute to be rendered, got schema:\n%s", schema)
+	}
+	if !strings.Contains(schema, "bottleList: [Bottle!]") {
+		t.Errorf("expected bottleList to return a list of Bottle, got schema:\n%s", schema)
+	}
+}
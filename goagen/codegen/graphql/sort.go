@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"sort"
+
+	"github.com/goadesign/goa/design"
+)
+
+// sortedResourceNames returns api's resource names in alphabetical order so schema generation is
+// reproducible across runs.
+func sortedResourceNames(api *design.APIDefinition) []string {
+	names := make([]string, 0, len(api.Resources))
+	for n := range api.Resources {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedActionNames returns res's action names in alphabetical order.
+func sortedActionNames(res *design.ResourceDefinition) []string {
+	names := make([]string, 0, len(res.Actions))
+	for n := range res.Actions {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedResponseNames returns act's response names in alphabetical order.
+func sortedResponseNames(act *design.ActionDefinition) []string {
+	names := make([]string, 0, len(act.Responses))
+	for n := range act.Responses {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedAttributeNames returns the attribute names selected by view in alphabetical order.
+func sortedAttributeNames(view *design.ViewDefinition) []string {
+	return sortedObjectAttributeNames(view.Type.ToObject())
+}
+
+// sortedObjectAttributeNames returns obj's attribute names in alphabetical order.
+func sortedObjectAttributeNames(obj design.Object) []string {
+	names := make([]string, 0, len(obj))
+	for n := range obj {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedKeys returns m's keys in alphabetical order.
+func sortedKeys(m map[string]string) []string {
+	names := make([]string, 0, len(m))
+	for n := range m {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedScalarNames returns the custom scalar names recorded in scalars in alphabetical order.
+func sortedScalarNames(scalars map[string]bool) []string {
+	names := make([]string, 0, len(scalars))
+	for n := range scalars {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedViewNames returns mt's view names in alphabetical order.
+func sortedViewNames(mt *design.MediaTypeDefinition) []string {
+	names := make([]string, 0, len(mt.Views))
+	for n := range mt.Views {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
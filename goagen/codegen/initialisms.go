@@ -0,0 +1,55 @@
+package codegen
+
+import "strings"
+
+// initialisms is the set of words that Goify renders in all caps instead of Title-casing, e.g.
+// "API" rather than "Api". It is seeded with the well-known Go style guide initialisms and can be
+// extended with RegisterInitialism.
+var initialisms = map[string]bool{
+	"ACL":   true,
+	"API":   true,
+	"ASCII": true,
+	"CPU":   true,
+	"CSS":   true,
+	"DNS":   true,
+	"EOF":   true,
+	"GUID":  true,
+	"HTML":  true,
+	"HTTP":  true,
+	"HTTPS": true,
+	"ID":    true,
+	"IP":    true,
+	"JSON":  true,
+	"LHS":   true,
+	"OK":    true,
+	"QPS":   true,
+	"RAM":   true,
+	"RHS":   true,
+	"RPC":   true,
+	"SLA":   true,
+	"SMTP":  true,
+	"SQL":   true,
+	"SSH":   true,
+	"TCP":   true,
+	"TLS":   true,
+	"TTL":   true,
+	"UDP":   true,
+	"UI":    true,
+	"UID":   true,
+	"UUID":  true,
+	"URI":   true,
+	"URL":   true,
+	"UTF8":  true,
+	"VM":    true,
+	"XML":   true,
+	"XMPP":  true,
+	"XSRF":  true,
+	"XSS":   true,
+}
+
+// RegisterInitialism adds word (case-insensitively) to the set of initialisms Goify renders in
+// all caps, e.g. RegisterInitialism("oauth") makes Goify produce "OAUTHToken" rather than
+// "OauthToken" for the DSL name "oauth_token".
+func RegisterInitialism(word string) {
+	initialisms[strings.ToUpper(word)] = true
+}
@@ -0,0 +1,66 @@
+package codegen
+
+import (
+	"strings"
+
+	"github.com/goadesign/goa/design"
+)
+
+// FieldNamingStrategy computes the wire name (JSON/XML tag value) for a DSL attribute name.
+type FieldNamingStrategy func(string) string
+
+// fieldNameMetadataKey is the Metadata key consulted to override the computed wire name for a
+// single field, regardless of DefaultFieldNamingStrategy.
+const fieldNameMetadataKey = "struct:field:name"
+
+// Built-in FieldNamingStrategy implementations.
+var (
+	// IdentityNaming leaves the DSL attribute name untouched, this was goa's only behavior
+	// before FieldNamingStrategy was introduced.
+	IdentityNaming FieldNamingStrategy = func(name string) string { return name }
+
+	// SnakeCaseNaming renders "userName" or "UserName" as "user_name".
+	SnakeCaseNaming FieldNamingStrategy = snakeCase
+
+	// CamelCaseNaming renders "user_name" as "userName".
+	CamelCaseNaming FieldNamingStrategy = func(name string) string { return Goify(name, false) }
+
+	// KebabCaseNaming renders "user_name" as "user-name".
+	KebabCaseNaming FieldNamingStrategy = func(name string) string {
+		return strings.Replace(snakeCase(name), "_", "-", -1)
+	}
+)
+
+// DefaultFieldNamingStrategy is the FieldNamingStrategy used to compute the wire name of struct
+// fields that don't carry a "struct:field:name" Metadata override. Generators may set it once
+// before running codegen, e.g. codegen.DefaultFieldNamingStrategy = codegen.SnakeCaseNaming.
+var DefaultFieldNamingStrategy FieldNamingStrategy = IdentityNaming
+
+// wireName returns the wire (JSON/XML/...) name to use for the DSL attribute name, honoring a
+// per-field "struct:field:name" Metadata override and otherwise applying
+// DefaultFieldNamingStrategy. Tag providers that emit a name-keyed tag (json, xml, form, ...)
+// should call this rather than using name directly so that renames stay consistent across all
+// enabled providers.
+func wireName(field *design.AttributeDefinition, name string) string {
+	if override, ok := field.Metadata[fieldNameMetadataKey]; ok && len(override) > 0 {
+		return override[0]
+	}
+	return DefaultFieldNamingStrategy(name)
+}
+
+// snakeCase lower-cases name and inserts an underscore before each upper case letter that follows
+// a lower case letter or digit, e.g. "UserName" and "userName" both become "user_name".
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && (r >= 'A' && r <= 'Z') {
+			prev := runes[i-1]
+			if prev != '_' && (prev >= 'a' && prev <= 'z' || prev >= '0' && prev <= '9') {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
+}
@@ -0,0 +1,145 @@
+package codegen
+
+import (
+	"fmt"
+
+	"github.com/goadesign/goa/design"
+	"github.com/goadesign/goa/dslengine"
+)
+
+// TagProvider computes the struct tag(s) contributed by a single tag kind (json, xml, form,
+// validate, bson, ...) for a struct field. Providers are registered with RegisterTagProvider and
+// enabled per call to GoTypeDef by passing their name in the tagProviderNames argument.
+// GoPackageTypeName and GoTypeRef take no such argument: the inline object types they render have
+// untagged fields.
+type TagProvider interface {
+	// Name identifies the provider, it is the string callers put in the tagProviderNames argument
+	// to GoTypeDef to enable it, and the Metadata key prefix
+	// ("struct:tag:<name>") consulted for per-field overrides.
+	Name() string
+	// Tags returns the backtick-free struct tag fragment(s) (e.g. `validate:"required,email"`)
+	// to emit for field given its DSL attribute name and whether it is required. It returns nil
+	// if the provider has nothing to contribute for this field.
+	Tags(field *design.AttributeDefinition, name string, required bool) []string
+}
+
+// tagProviders is the registry of available TagProvider implementations, indexed by Name().
+var tagProviders = map[string]TagProvider{}
+
+func init() {
+	RegisterTagProvider(jsonTagProvider{})
+	RegisterTagProvider(xmlTagProvider{})
+	RegisterTagProvider(validateTagProvider{})
+}
+
+// RegisterTagProvider makes p available for use in the tagProviderNames list passed to GoTypeDef.
+// Registering a provider under a name that is already taken replaces it, this
+// lets callers override the built-in "json"/"xml"/"validate" providers.
+func RegisterTagProvider(p TagProvider) {
+	tagProviders[p.Name()] = p
+}
+
+// structTags returns the struct tag string (including the surrounding backticks) produced by
+// running the named providers, in the order given, over field. A field-level "struct:tag:<name>"
+// Metadata entry, when present, is used verbatim instead of calling the provider, whether or not
+// a TagProvider is registered under that name. Without such an entry, names with no registered
+// TagProvider are silently ignored.
+func structTags(providers []string, field *design.AttributeDefinition, name string, required bool) string {
+	if len(providers) == 0 {
+		return ""
+	}
+	var tags []string
+	for _, pname := range providers {
+		if override, ok := field.Metadata["struct:tag:"+pname]; ok {
+			tags = append(tags, override...)
+			continue
+		}
+		p, ok := tagProviders[pname]
+		if !ok {
+			continue
+		}
+		tags = append(tags, p.Tags(field, name, required)...)
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	return " `" + joinTags(tags) + "`"
+}
+
+// joinTags joins already-formatted "key:\"value\"" tag fragments with a single space, the way the
+// standard library's reflect.StructTag expects.
+func joinTags(tags []string) string {
+	res := tags[0]
+	for _, t := range tags[1:] {
+		res += " " + t
+	}
+	return res
+}
+
+// jsonTagProvider emits the `json:"name[,omitempty]"` struct tag, preserving the behavior goa has
+// always had when jsonTags was passed as true.
+type jsonTagProvider struct{}
+
+func (jsonTagProvider) Name() string { return "json" }
+
+func (jsonTagProvider) Tags(field *design.AttributeDefinition, name string, required bool) []string {
+	var omit string
+	if !required {
+		omit = ",omitempty"
+	}
+	return []string{fmt.Sprintf(`json:"%s%s"`, wireName(field, name), omit)}
+}
+
+// xmlTagProvider emits the `xml:"name[,omitempty]"` struct tag, preserving the behavior goa has
+// always had when jsonTags was passed as true.
+type xmlTagProvider struct{}
+
+func (xmlTagProvider) Name() string { return "xml" }
+
+func (xmlTagProvider) Tags(field *design.AttributeDefinition, name string, required bool) []string {
+	var omit string
+	if !required {
+		omit = ",omitempty"
+	}
+	return []string{fmt.Sprintf(`xml:"%s%s"`, wireName(field, name), omit)}
+}
+
+// validateTagProvider emits a `validate:"..."` struct tag (github.com/go-playground/validator
+// syntax) derived from the field's dslengine validations and required-ness.
+type validateTagProvider struct{}
+
+func (validateTagProvider) Name() string { return "validate" }
+
+func (validateTagProvider) Tags(field *design.AttributeDefinition, name string, required bool) []string {
+	var rules []string
+	if required {
+		rules = append(rules, "required")
+	}
+	for _, v := range field.Validations {
+		switch actual := v.(type) {
+		case *dslengine.MinimumValidationDefinition:
+			rules = append(rules, fmt.Sprintf("min=%v", actual.Min))
+		case *dslengine.MaximumValidationDefinition:
+			rules = append(rules, fmt.Sprintf("max=%v", actual.Max))
+		case *dslengine.MinLengthValidationDefinition:
+			rules = append(rules, fmt.Sprintf("min=%d", actual.MinLength))
+		case *dslengine.MaxLengthValidationDefinition:
+			rules = append(rules, fmt.Sprintf("max=%d", actual.MaxLength))
+		case *dslengine.FormatValidationDefinition:
+			switch actual.Format {
+			case "email":
+				rules = append(rules, "email")
+			case "uuid":
+				rules = append(rules, "uuid")
+			}
+		}
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	joined := rules[0]
+	for _, r := range rules[1:] {
+		joined += "," + r
+	}
+	return []string{fmt.Sprintf(`validate:"%s"`, joined)}
+}
@@ -24,8 +24,10 @@ var (
 // default package (false).
 // tabs is the number of tab character(s) used to tabulate the definition however the first
 // line is never indented.
-// jsonTags controls whether to produce json tags.
-func GoTypeDef(ds design.DataStructure, versioned bool, defPkg string, tabs int, jsonTags bool) string {
+// tagProviderNames is the list of registered TagProvider names (see RegisterTagProvider) to
+// consult when producing struct field tags, e.g. []string{"json", "xml"}. A nil or empty slice
+// produces untagged fields.
+func GoTypeDef(ds design.DataStructure, versioned bool, defPkg string, tabs int, tagProviderNames []string) string {
 	var buffer bytes.Buffer
 	def := ds.Definition()
 	t := def.Type
@@ -33,17 +35,17 @@ func GoTypeDef(ds design.DataStructure, versioned bool, defPkg string, tabs int,
 	case design.Primitive:
 		return GoTypeName(t, nil, tabs)
 	case *design.Array:
-		d := GoTypeDef(actual.ElemType, versioned, defPkg, tabs, jsonTags)
+		d := GoTypeDef(actual.ElemType, versioned, defPkg, tabs, tagProviderNames)
 		if actual.ElemType.Type.IsObject() {
 			d = "*" + d
 		}
 		return "[]" + d
 	case *design.Hash:
-		keyDef := GoTypeDef(actual.KeyType, versioned, defPkg, tabs, jsonTags)
+		keyDef := GoTypeDef(actual.KeyType, versioned, defPkg, tabs, tagProviderNames)
 		if actual.KeyType.Type.IsObject() {
 			keyDef = "*" + keyDef
 		}
-		elemDef := GoTypeDef(actual.ElemType, versioned, defPkg, tabs, jsonTags)
+		elemDef := GoTypeDef(actual.ElemType, versioned, defPkg, tabs, tagProviderNames)
 		if actual.ElemType.Type.IsObject() {
 			elemDef = "*" + elemDef
 		}
@@ -60,19 +62,12 @@ func GoTypeDef(ds design.DataStructure, versioned bool, defPkg string, tabs int,
 		for _, name := range keys {
 			WriteTabs(&buffer, tabs+1)
 			field := actual[name]
-			typedef := GoTypeDef(field, versioned, defPkg, tabs+1, jsonTags)
+			typedef := GoTypeDef(field, versioned, defPkg, tabs+1, tagProviderNames)
 			if field.Type.IsObject() || def.IsPrimitivePointer(name) {
 				typedef = "*" + typedef
 			}
 			fname := Goify(name, true)
-			var tags string
-			if jsonTags {
-				var omit string
-				if !def.IsRequired(name) {
-					omit = ",omitempty"
-				}
-				tags = fmt.Sprintf(" `json:\"%s%s\" xml:\"%s%s\"`", name, omit, name, omit)
-			}
+			tags := structTags(tagProviderNames, field, name, def.IsRequired(name))
 			desc := actual[name].Description
 			if desc != "" {
 				desc = fmt.Sprintf("// %s\n", desc)
@@ -83,9 +78,19 @@ func GoTypeDef(ds design.DataStructure, versioned bool, defPkg string, tabs int,
 		buffer.WriteString("}")
 		return buffer.String()
 	case *design.UserTypeDefinition:
-		return GoPackageTypeName(actual, actual.AllRequired(), versioned, defPkg, tabs)
+		name := GoPackageTypeName(actual, actual.AllRequired(), versioned, defPkg, tabs)
+		// Struct fields, array elements and hash keys/values are *design.AttributeDefinition
+		// values: they reference the type and never take the alias form.
+		if _, isAtt := ds.(*design.AttributeDefinition); !isAtt && shouldEmitAlias(def) {
+			return "= " + name
+		}
+		return name
 	case *design.MediaTypeDefinition:
-		return GoPackageTypeName(actual, actual.AllRequired(), versioned, defPkg, tabs)
+		name := GoPackageTypeName(actual, actual.AllRequired(), versioned, defPkg, tabs)
+		if _, isAtt := ds.(*design.AttributeDefinition); !isAtt && shouldEmitAlias(def) {
+			return "= " + name
+		}
+		return name
 	default:
 		panic("goa bug: unknown data structure type")
 	}
@@ -141,6 +146,9 @@ func GoTypeName(t design.DataType, required []string, tabs int) string {
 // case the type (Object) does not carry the required field information defined in the parent
 // (anonymous) attribute.
 // tabs is used to properly tabulate the object struct fields and only applies to this case.
+// If a binding is registered on DefaultBinder for t (see Binder), the bound Go type name is
+// returned and the package prefix logic below is bypassed since the bound name already carries
+// any package qualifier it needs.
 func GoPackageTypeName(t design.DataType, required []string, versioned bool, defPkg string, tabs int) string {
 	switch actual := t.(type) {
 	case design.Primitive:
@@ -153,7 +161,7 @@ func GoPackageTypeName(t design.DataType, required []string, versioned bool, def
 			requiredVal := &dslengine.RequiredValidationDefinition{Names: required}
 			att.Validations = append(att.Validations, requiredVal)
 		}
-		return GoTypeDef(att, versioned, defPkg, tabs, false)
+		return GoTypeDef(att, versioned, defPkg, tabs, nil)
 	case *design.Hash:
 		return fmt.Sprintf(
 			"map[%s]%s",
@@ -161,9 +169,15 @@ func GoPackageTypeName(t design.DataType, required []string, versioned bool, def
 			GoPackageTypeRef(actual.ElemType.Type, actual.ElemType.AllRequired(), versioned, defPkg, tabs+1),
 		)
 	case *design.UserTypeDefinition:
+		if name, ok := boundUserTypeName(actual); ok {
+			return name
+		}
 		pkgPrefix := PackagePrefix(actual, versioned, defPkg)
 		return pkgPrefix + Goify(actual.TypeName, true)
 	case *design.MediaTypeDefinition:
+		if name, ok := boundUserTypeName(actual.UserTypeDefinition); ok {
+			return name
+		}
 		pkgPrefix := PackagePrefix(actual.UserTypeDefinition, versioned, defPkg)
 		return pkgPrefix + Goify(actual.TypeName, true)
 	default:
@@ -171,10 +185,15 @@ func GoPackageTypeName(t design.DataType, required []string, versioned bool, def
 	}
 }
 
-// GoNativeType returns the Go built-in type from which instances of t can be initialized.
+// GoNativeType returns the Go built-in type from which instances of t can be initialized. If a
+// binding is registered on DefaultBinder for t's kind or (for user types) for its name, the bound
+// Go type name is returned instead.
 func GoNativeType(t design.DataType) string {
 	switch actual := t.(type) {
 	case design.Primitive:
+		if name, ok := boundKindName(actual.Kind()); ok {
+			return name
+		}
 		switch actual.Kind() {
 		case design.BooleanKind:
 			return "bool"
@@ -198,8 +217,14 @@ func GoNativeType(t design.DataType) string {
 	case *design.Hash:
 		return fmt.Sprintf("map[%s]%s", GoNativeType(actual.KeyType.Type), GoNativeType(actual.ElemType.Type))
 	case *design.MediaTypeDefinition:
+		if name, ok := boundUserTypeName(actual.UserTypeDefinition); ok {
+			return name
+		}
 		return GoNativeType(actual.Type)
 	case *design.UserTypeDefinition:
+		if name, ok := boundUserTypeName(actual); ok {
+			return name
+		}
 		return GoNativeType(actual.Type)
 	default:
 		panic(fmt.Sprintf("goa bug: unknown type %#v", actual))
@@ -210,38 +235,36 @@ func GoNativeType(t design.DataType) string {
 // It does that by removing any non letter and non digit character and by making sure the first
 // character is a letter or "_".
 // Goify produces a "CamelCase" version of the string, if firstUpper is true the first character
-// of the identifier is uppercase otherwise it's lowercase.
+// of the identifier is uppercase otherwise it's lowercase. Words that match an entry in the
+// initialisms set (see RegisterInitialism), e.g. "api" or "url", are rendered in all caps instead
+// of Title-case, so "user_url" becomes "UserURL" rather than "UserUrl".
 func Goify(str string, firstUpper bool) string {
-	if str == "ok" && firstUpper {
-		return "OK"
-	} else if str == "id" && firstUpper {
-		return "ID"
+	words := splitWords(str)
+	if len(words) == 0 {
+		return "_v" // you have a better idea?
 	}
 	var b bytes.Buffer
-	var firstWritten, nextUpper bool
-	for i := 0; i < len(str); i++ {
-		r := rune(str[i])
-		if r == '_' {
-			nextUpper = true
-		} else if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			if !firstWritten {
-				if firstUpper {
-					r = unicode.ToUpper(r)
-				} else {
-					r = unicode.ToLower(r)
-				}
-				firstWritten = true
-				nextUpper = false
-			} else if nextUpper {
-				r = unicode.ToUpper(r)
-				nextUpper = false
+	for i, w := range words {
+		upper := strings.ToUpper(w)
+		if initialisms[upper] {
+			if i == 0 && !firstUpper {
+				b.WriteString(strings.ToLower(w))
+			} else {
+				b.WriteString(upper)
 			}
+			continue
+		}
+		runes := []rune(w)
+		if i == 0 && !firstUpper {
+			runes[0] = unicode.ToLower(runes[0])
+		} else {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		b.WriteRune(runes[0])
+		for _, r := range runes[1:] {
 			b.WriteRune(r)
 		}
 	}
-	if b.Len() == 0 {
-		return "_v" // you have a better idea?
-	}
 	res := b.String()
 	if _, ok := reserved[res]; ok {
 		res += "_"
@@ -249,6 +272,35 @@ func Goify(str string, firstUpper bool) string {
 	return res
 }
 
+// splitWords splits str into the words Goify capitalizes independently: "_" always starts a new
+// word, as does an upper case letter that immediately follows a lower case letter or digit (a
+// camelCase boundary). Any character that is neither a letter nor a digit is dropped.
+func splitWords(str string) []string {
+	var words []string
+	var cur []rune
+	var prev rune
+	flush := func() {
+		if len(cur) > 0 {
+			words = append(words, string(cur))
+			cur = nil
+		}
+	}
+	for _, r := range str {
+		switch {
+		case r == '_':
+			flush()
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if len(cur) > 0 && unicode.IsUpper(r) && !unicode.IsUpper(prev) {
+				flush()
+			}
+			cur = append(cur, r)
+		}
+		prev = r
+	}
+	flush()
+	return words
+}
+
 // WriteTabs is a helper function that writes count tabulation characters to buf.
 func WriteTabs(buf *bytes.Buffer, count int) {
 	for i := 0; i < count; i++ {